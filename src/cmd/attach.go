@@ -0,0 +1,106 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/containers/toolbox/pkg/podman"
+	"github.com/containers/toolbox/pkg/shell"
+	"github.com/containers/toolbox/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attachFlags struct {
+		detachKeys string
+	}
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach CONTAINER",
+	Short: "Reconnect to a toolbox session you previously detached from",
+	Args:  cobra.ExactArgs(1),
+	RunE:  attach,
+}
+
+func init() {
+	flags := attachCmd.Flags()
+
+	flags.StringVar(&attachFlags.detachKeys,
+		"detach-keys",
+		"",
+		"Override the key sequence for detaching again, e.g. 'ctrl-p,ctrl-q'.")
+
+	attachCmd.SetHelpFunc(attachHelp)
+	rootCmd.AddCommand(attachCmd)
+}
+
+func attach(cmd *cobra.Command, args []string) error {
+	if utils.IsInsideContainer() {
+		return errors.New("toolbox attach can only be run on the host")
+	}
+
+	container := args[0]
+
+	if _, err := utils.IsContainerNameValid(container); err != nil {
+		return fmt.Errorf("invalid argument for 'CONTAINER'\nContainer names must match '%s'", utils.ContainerNameRegexp)
+	}
+
+	if _, err := podman.ContainerExists(container); err != nil {
+		err := utils.CreateErrorContainerNotFound(container, executableBase)
+		return err
+	}
+
+	detachKeys := attachFlags.detachKeys
+	if detachKeys == "" {
+		config, err := utils.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		detachKeys = config.DetachKeys
+	}
+
+	attachArgs := []string{"attach"}
+
+	if detachKeys != "" {
+		attachArgs = append(attachArgs, "--detach-keys", detachKeys)
+	}
+
+	attachArgs = append(attachArgs, container)
+
+	exitCode, err := shell.RunWithExitCodeAndResize("podman", os.Stdin, os.Stdout, nil, attachArgs...)
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("failed to attach to container %s", container)
+	}
+
+	return nil
+}
+
+func attachHelp(cmd *cobra.Command, args []string) {
+	if err := utils.ShowManual("toolbox-attach"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return
+	}
+}
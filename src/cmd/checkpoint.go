@@ -0,0 +1,144 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/toolbox/pkg/podman"
+	"github.com/containers/toolbox/pkg/shell"
+	"github.com/containers/toolbox/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkpointFlags struct {
+		export string
+	}
+)
+
+var checkpointCmd = &cobra.Command{
+	Use:   "checkpoint CONTAINER",
+	Short: "Freeze a running toolbox container to disk using CRIU",
+	RunE:  checkpoint,
+}
+
+func init() {
+	flags := checkpointCmd.Flags()
+
+	flags.StringVar(&checkpointFlags.export,
+		"export",
+		"",
+		"Export the checkpoint to the given tarball instead of the default checkpoint store.")
+
+	checkpointCmd.SetHelpFunc(checkpointHelp)
+	rootCmd.AddCommand(checkpointCmd)
+}
+
+func checkpoint(cmd *cobra.Command, args []string) error {
+	if utils.IsInsideContainer() {
+		return errors.New("toolbox checkpoint can only be run on the host")
+	}
+
+	if len(args) != 1 {
+		return errors.New("missing argument for 'CONTAINER'")
+	}
+
+	container := args[0]
+
+	if _, err := utils.IsContainerNameValid(container); err != nil {
+		return fmt.Errorf("invalid argument for 'CONTAINER'\nContainer names must match '%s'", utils.ContainerNameRegexp)
+	}
+
+	if _, err := podman.ContainerExists(container); err != nil {
+		err := utils.CreateErrorContainerNotFound(container, executableBase)
+		return err
+	}
+
+	exportPath := checkpointFlags.export
+	if exportPath == "" {
+		var err error
+		exportPath, err = defaultCheckpointPath(container)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(exportPath), 0700); err != nil {
+			return fmt.Errorf("failed to create checkpoint directory for %s: %w", container, err)
+		}
+	}
+
+	checkpointArgs := []string{
+		"container", "checkpoint",
+		"--export", exportPath,
+		container,
+	}
+
+	exitCode, err := shell.RunWithExitCode("podman", nil, os.Stdout, os.Stderr, checkpointArgs...)
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("failed to checkpoint container %s", container)
+	}
+
+	if err := recordCheckpointPath(container, exportPath); err != nil {
+		return fmt.Errorf("checkpointed container %s but failed to record its location: %w", container, err)
+	}
+
+	fmt.Printf("Checkpointed container %s to %s\n", container, exportPath)
+
+	return nil
+}
+
+// resolveCheckpointPath finds the tarball for a checkpointed container:
+// the path recorded by 'toolbox checkpoint' (whether that was the
+// default store or a custom '--export' location), falling back to
+// defaultCheckpointPath for checkpoints made before the manifest
+// existed.
+func resolveCheckpointPath(container string) (string, error) {
+	if path, ok, err := lookupCheckpointPath(container); err != nil {
+		return "", err
+	} else if ok {
+		return path, nil
+	}
+
+	return defaultCheckpointPath(container)
+}
+
+// defaultCheckpointPath is where a checkpoint is stored when --export is
+// not given, so that 'toolbox restore' can find it without the user
+// having to keep track of the tarball themselves.
+func defaultCheckpointPath(container string) (string, error) {
+	dataHome, err := utils.GetDataHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dataHome, "toolbox", "checkpoints", container+".tar"), nil
+}
+
+func checkpointHelp(cmd *cobra.Command, args []string) {
+	if err := utils.ShowManual("toolbox-checkpoint"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return
+	}
+}
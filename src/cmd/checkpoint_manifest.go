@@ -0,0 +1,107 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/toolbox/pkg/utils"
+)
+
+// checkpointManifest maps a container name to the checkpoint tarball
+// that was last made for it, whether that tarball lives at
+// defaultCheckpointPath or was written to a custom '--export' location.
+// Without it, a custom '--export' path is invisible to 'toolbox restore'
+// (run without '--import') and to enterContainer's auto-restore.
+type checkpointManifest map[string]string
+
+func checkpointManifestPath() (string, error) {
+	dataHome, err := utils.GetDataHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dataHome, "toolbox", "checkpoints", "index.json"), nil
+}
+
+func loadCheckpointManifest() (checkpointManifest, error) {
+	path, err := checkpointManifestPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpointManifest{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	manifest := checkpointManifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return manifest, nil
+}
+
+// recordCheckpointPath remembers where the checkpoint for container was
+// written, so it can be found again without the caller passing
+// '--import' explicitly.
+func recordCheckpointPath(container, path string) error {
+	manifest, err := loadCheckpointManifest()
+	if err != nil {
+		return err
+	}
+
+	manifest[container] = path
+
+	manifestPath, err := checkpointManifestPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint manifest: %w", err)
+	}
+
+	if err := os.WriteFile(manifestPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifestPath, err)
+	}
+
+	return nil
+}
+
+// lookupCheckpointPath returns the tarball last recorded for container,
+// if any.
+func lookupCheckpointPath(container string) (string, bool, error) {
+	manifest, err := loadCheckpointManifest()
+	if err != nil {
+		return "", false, err
+	}
+
+	path, ok := manifest[container]
+	return path, ok, nil
+}
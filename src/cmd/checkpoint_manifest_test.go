@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointManifestRoundTrip(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if _, ok, err := lookupCheckpointPath("fedora-toolbox-39"); err != nil {
+		t.Fatalf("lookupCheckpointPath on empty manifest failed: %v", err)
+	} else if ok {
+		t.Fatal("lookupCheckpointPath found an entry in an empty manifest")
+	}
+
+	if err := recordCheckpointPath("fedora-toolbox-39", "/var/tmp/fedora-toolbox-39.tar"); err != nil {
+		t.Fatalf("recordCheckpointPath failed: %v", err)
+	}
+
+	path, ok, err := lookupCheckpointPath("fedora-toolbox-39")
+	if err != nil {
+		t.Fatalf("lookupCheckpointPath failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("lookupCheckpointPath did not find the recorded entry")
+	}
+	if path != "/var/tmp/fedora-toolbox-39.tar" {
+		t.Errorf("path = %q, want %q", path, "/var/tmp/fedora-toolbox-39.tar")
+	}
+
+	manifestPath, err := checkpointManifestPath()
+	if err != nil {
+		t.Fatalf("checkpointManifestPath failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Dir(manifestPath)); err != nil {
+		t.Errorf("manifest directory was not created: %v", err)
+	}
+}
+
+func TestCheckpointManifestOverwrite(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	if err := recordCheckpointPath("fedora-toolbox-39", "/var/tmp/first.tar"); err != nil {
+		t.Fatalf("recordCheckpointPath failed: %v", err)
+	}
+
+	if err := recordCheckpointPath("fedora-toolbox-39", "/var/tmp/second.tar"); err != nil {
+		t.Fatalf("recordCheckpointPath failed: %v", err)
+	}
+
+	path, ok, err := lookupCheckpointPath("fedora-toolbox-39")
+	if err != nil {
+		t.Fatalf("lookupCheckpointPath failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("lookupCheckpointPath did not find the recorded entry")
+	}
+	if path != "/var/tmp/second.tar" {
+		t.Errorf("path = %q, want %q, a later checkpoint should replace the earlier record", path, "/var/tmp/second.tar")
+	}
+}
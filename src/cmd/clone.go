@@ -0,0 +1,157 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/toolbox/pkg/podman"
+	"github.com/containers/toolbox/pkg/shell"
+	"github.com/containers/toolbox/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneFlags struct {
+		container string
+		from      string
+		keepImage bool
+		start     bool
+	}
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "Duplicate an existing toolbox container under a new name",
+	RunE:  clone,
+}
+
+func init() {
+	flags := cloneCmd.Flags()
+
+	flags.StringVar(&cloneFlags.container,
+		"container",
+		"",
+		"Name for the new, cloned container.")
+
+	flags.StringVar(&cloneFlags.from,
+		"from",
+		"",
+		"Name of the existing toolbox container to clone.")
+
+	flags.BoolVar(&cloneFlags.keepImage,
+		"keep-image",
+		false,
+		"Don't try to remove the intermediate image used to create the clone. Removal is refused while the clone still uses the image, so it is kept either way.")
+
+	flags.BoolVar(&cloneFlags.start,
+		"start",
+		false,
+		"Start the cloned container after creating it.")
+
+	cloneCmd.SetHelpFunc(cloneHelp)
+	rootCmd.AddCommand(cloneCmd)
+}
+
+func clone(cmd *cobra.Command, args []string) error {
+	if utils.IsInsideContainer() {
+		return errors.New("toolbox clone can only be run on the host")
+	}
+
+	if cloneFlags.from == "" {
+		return errors.New("missing required flag '--from'")
+	}
+
+	if cloneFlags.container == "" {
+		return errors.New("missing required flag '--container'")
+	}
+
+	if _, err := utils.IsContainerNameValid(cloneFlags.from); err != nil {
+		var builder strings.Builder
+		fmt.Fprintf(&builder, "invalid argument for '--from'\n")
+		fmt.Fprintf(&builder, "Container names must match '%s'\n", utils.ContainerNameRegexp)
+		fmt.Fprintf(&builder, "Run '%s --help' for usage.", executableBase)
+
+		errMsg := builder.String()
+		return errors.New(errMsg)
+	}
+
+	if _, err := utils.IsContainerNameValid(cloneFlags.container); err != nil {
+		var builder strings.Builder
+		fmt.Fprintf(&builder, "invalid argument for '--container'\n")
+		fmt.Fprintf(&builder, "Container names must match '%s'\n", utils.ContainerNameRegexp)
+		fmt.Fprintf(&builder, "Run '%s --help' for usage.", executableBase)
+
+		errMsg := builder.String()
+		return errors.New(errMsg)
+	}
+
+	if _, err := podman.ContainerExists(cloneFlags.from); err != nil {
+		err := utils.CreateErrorContainerNotFound(cloneFlags.from, executableBase)
+		return err
+	}
+
+	if _, err := podman.ContainerExists(cloneFlags.container); err == nil {
+		return fmt.Errorf("container %s already exists", cloneFlags.container)
+	}
+
+	image := fmt.Sprintf("localhost/toolbox-clone-%s:%d", cloneFlags.from, time.Now().Unix())
+
+	fmt.Printf("Creating image %s from container %s\n", image, cloneFlags.from)
+
+	commitArgs := []string{"container", "commit", cloneFlags.from, image}
+	if exitCode, err := shell.RunWithExitCode("podman", nil, os.Stdout, os.Stderr, commitArgs...); err != nil || exitCode != 0 {
+		return fmt.Errorf("failed to commit container %s", cloneFlags.from)
+	}
+
+	if err := createContainer(cloneFlags.container, image, "", false); err != nil {
+		return fmt.Errorf("failed to create clone %s: %w", cloneFlags.container, err)
+	}
+
+	if !cloneFlags.keepImage {
+		// The new container still references 'image', so a plain 'podman
+		// rmi' is expected to refuse to remove it here; that's fine, it
+		// just means the image stays around as long as the clone does.
+		// '--force' would remove it regardless, taking the clone
+		// container down with it, so it must not be used.
+		rmiArgs := []string{"rmi", image}
+		if exitCode, err := shell.RunWithExitCode("podman", nil, os.Stdout, os.Stderr, rmiArgs...); err != nil || exitCode != 0 {
+			fmt.Fprintf(os.Stderr, "Note: kept intermediate image %s; it is still used by %s\n", image, cloneFlags.container)
+		}
+	}
+
+	fmt.Printf("Created container %s from %s\n", cloneFlags.container, cloneFlags.from)
+
+	if cloneFlags.start {
+		if err := startContainer(cloneFlags.container); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func cloneHelp(cmd *cobra.Command, args []string) {
+	if err := utils.ShowManual("toolbox-clone"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return
+	}
+}
@@ -32,8 +32,11 @@ import (
 
 var (
 	enterFlags struct {
-		container string
-		release   string
+		container  string
+		release    string
+		connection string
+		url        string
+		detachKeys string
 	}
 )
 
@@ -58,6 +61,21 @@ func init() {
 		"",
 		"Enter a toolbox container for a different operating system release than the host.")
 
+	flags.StringVar(&enterFlags.connection,
+		"connection",
+		"",
+		"Enter a toolbox container on a named remote connection (see connections.conf).")
+
+	flags.StringVar(&enterFlags.url,
+		"url",
+		"",
+		"Enter a toolbox container on a remote podman reachable at the given SSH URL.")
+
+	flags.StringVar(&enterFlags.detachKeys,
+		"detach-keys",
+		"",
+		"Override the key sequence for detaching from the container, e.g. 'ctrl-p,ctrl-q'.")
+
 	enterCmd.SetHelpFunc(enterHelp)
 	rootCmd.AddCommand(enterCmd)
 }
@@ -113,6 +131,10 @@ func enter(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err := resolveTransport(enterFlags.connection, enterFlags.url); err != nil {
+		return err
+	}
+
 	container, image, release, err := utils.ResolveContainerAndImageNames(container, "", release)
 	if err != nil {
 		return err
@@ -137,10 +159,21 @@ func enter(cmd *cobra.Command, args []string) error {
 
 	var emitEscapeSequence bool
 
-	if hostID == "fedora" && (hostVariantID == "silverblue" || hostVariantID == "workstation") {
+	if !podman.ActiveTransport.IsRemote() &&
+		hostID == "fedora" && (hostVariantID == "silverblue" || hostVariantID == "workstation") {
 		emitEscapeSequence = true
 	}
 
+	detachKeys := enterFlags.detachKeys
+	if detachKeys == "" {
+		config, err := utils.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		detachKeys = config.DetachKeys
+	}
+
 	if err := enterContainer(container,
 		!nonDefaultContainer,
 		image,
@@ -148,18 +181,46 @@ func enter(cmd *cobra.Command, args []string) error {
 		command,
 		emitEscapeSequence,
 		true,
-		false); err != nil {
+		false,
+		detachKeys); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// resolveTransport sets podman.ActiveTransport from an explicit SSH URL,
+// a named connection looked up in connections.conf, or leaves it at its
+// zero value to target the local podman.
+func resolveTransport(connection, url string) error {
+	if connection != "" && url != "" {
+		return errors.New("only one of '--connection' or '--url' may be used")
+	}
+
+	if url != "" {
+		podman.ActiveTransport = podman.Transport{URL: url}
+		return podman.ActiveTransport.Apply()
+	}
+
+	if connection != "" {
+		conn, err := utils.GetConnection(connection)
+		if err != nil {
+			return err
+		}
+
+		podman.ActiveTransport = podman.Transport{URL: conn.URL, Identity: conn.Identity}
+		return podman.ActiveTransport.Apply()
+	}
+
+	return nil
+}
+
 func enterContainer(container string,
 	defaultContainer bool,
 	image, release string,
 	command []string,
-	emitEscapeSequence, fallbackToBash, pedantic bool) error {
+	emitEscapeSequence, fallbackToBash, pedantic bool,
+	detachKeys string) error {
 
 	if !pedantic {
 		if image == "" {
@@ -231,15 +292,64 @@ func enterContainer(container string,
 		}
 	}
 
-	if err := callFlatpakSessionHelper(container); err != nil {
-		return err
+	opts := ExecOptions{
+		User:               currentUser.Username,
+		Workdir:            workingDirectory,
+		EmitEscapeSequence: emitEscapeSequence,
+		FallbackToBash:     fallbackToBash,
+		DetachKeys:         detachKeys,
 	}
 
-	logrus.Debugf("Starting container %s", container)
-	if err := startContainer(container); err != nil {
+	return execInContainer(container, command, opts)
+}
+
+// ExecOptions holds the knobs that differ between 'toolbox enter' and
+// 'toolbox exec' when running a command inside an already-resolved
+// container. Everything else (readiness wait, capsh wrapping, env
+// preservation) is shared by execInContainer.
+type ExecOptions struct {
+	Detach             bool
+	User               string
+	Workdir            string
+	Env                []string
+	EmitEscapeSequence bool
+	FallbackToBash     bool
+	DetachKeys         string
+}
+
+// execInContainer prepares a container for use (Flatpak session helper,
+// start, wait for initialization) and then runs command inside it. It is
+// the common tail shared by 'toolbox enter' and 'toolbox exec'.
+func execInContainer(container string, command []string, opts ExecOptions) error {
+	if !podman.ActiveTransport.IsRemote() {
+		if err := callFlatpakSessionHelper(container); err != nil {
+			return err
+		}
+	}
+
+	checkpointed, err := podman.IsCheckpointed(container)
+	if err != nil {
 		return err
 	}
 
+	if checkpointed {
+		logrus.Debugf("Restoring checkpointed container %s", container)
+
+		checkpointPath, err := resolveCheckpointPath(container)
+		if err != nil {
+			return err
+		}
+
+		if err := restoreCheckpoint(container, checkpointPath); err != nil {
+			return err
+		}
+	} else {
+		logrus.Debugf("Starting container %s", container)
+		if err := startContainer(container); err != nil {
+			return err
+		}
+	}
+
 	entryPoint, entryPointPID, err := getEntryPointAndPID(container)
 	if err != nil {
 		return err
@@ -260,28 +370,42 @@ func enterContainer(container string,
 
 	logrus.Debugf("Waiting for container %s to finish initializing", container)
 
-	toolboxRuntimeDirectory, err := utils.GetRuntimeDirectory(currentUser)
+	hasHealthcheck, err := podman.HasHealthcheck(container)
 	if err != nil {
 		return err
 	}
 
-	initializedStamp := fmt.Sprintf("%s/container-initialized-%d", toolboxRuntimeDirectory, entryPointPID)
-
-	logrus.Debugf("Checking if initialization stamp %s exists", initializedStamp)
+	if hasHealthcheck {
+		if err := podman.WaitForHealthy(container, 25*time.Second); err != nil {
+			return fmt.Errorf("failed to initialize container %s: %w", container, err)
+		}
+	} else {
+		// Fall back to the older container-initialized-<pid> stamp for
+		// containers created without a healthcheck (see
+		// podman.HasHealthcheck for when a container has one).
+		logrus.Debugf("Container %s has no healthcheck, falling back to stamp file", container)
 
-	initializedTimeout := 25 // seconds
-	for i := 0; !utils.PathExists(initializedStamp); i++ {
-		if i == initializedTimeout {
-			return fmt.Errorf("failed to initialize container %s", container)
+		toolboxRuntimeDirectory, err := utils.GetRuntimeDirectory(currentUser)
+		if err != nil {
+			return err
 		}
 
-		time.Sleep(time.Second)
+		initializedStamp := fmt.Sprintf("%s/container-initialized-%d", toolboxRuntimeDirectory, entryPointPID)
+
+		initializedTimeout := 25 // seconds
+		for i := 0; !utils.PathExists(initializedStamp); i++ {
+			if i == initializedTimeout {
+				return fmt.Errorf("failed to initialize container %s", container)
+			}
+
+			time.Sleep(time.Second)
+		}
 	}
 
 	logrus.Debugf("Container %s is initialized", container)
 
 	if _, err := isCommandPresent(container, command[0]); err != nil {
-		if fallbackToBash {
+		if opts.FallbackToBash {
 			fmt.Fprintf(os.Stderr,
 				"Error: command %s not found in container %s\n",
 				command[0],
@@ -300,25 +424,44 @@ func enterContainer(container string,
 
 	if podman.CheckVersion("1.8.1") {
 		logrus.Debug("'podman exec' supports disabling the detach keys")
-		detachKeys = []string{"--detach-keys", ""}
+
+		if opts.DetachKeys != "" {
+			detachKeys = []string{"--detach-keys", opts.DetachKeys}
+		} else {
+			detachKeys = []string{"--detach-keys", ""}
+		}
+	} else if opts.DetachKeys != "" {
+		logrus.Debug("'podman exec' doesn't support disabling the detach keys; ignoring custom detach keys")
+	}
+
+	if opts.DetachKeys != "" && !opts.Detach && isInteractiveTTY() {
+		fmt.Printf("Detach keys: %s. Reconnect with '%s attach %s'.\n",
+			opts.DetachKeys, executableBase, container)
 	}
 
 	envOptions := utils.GetEnvOptionsForPreservedVariables()
+	for _, env := range opts.Env {
+		envOptions = append(envOptions, "--env", env)
+	}
+
 	logLevelString := podman.LogLevel.String()
 
 	execArgs := []string{
 		"--log-level", logLevelString,
-		"exec",
 	}
 
+	execArgs = append(execArgs, podman.ActiveTransport.GlobalArgs()...)
+	execArgs = append(execArgs, "exec")
 	execArgs = append(execArgs, detachKeys...)
 
-	execArgs = append(execArgs, []string{
-		"--interactive",
-		"--tty",
-		"--user", currentUser.Username,
-		"--workdir", workingDirectory,
-	}...)
+	if opts.Detach {
+		execArgs = append(execArgs, "--detach")
+	} else {
+		execArgs = append(execArgs, "--interactive", "--tty")
+	}
+
+	execArgs = append(execArgs, "--user", opts.User)
+	execArgs = append(execArgs, "--workdir", opts.Workdir)
 
 	execArgs = append(execArgs, envOptions...)
 
@@ -329,7 +472,7 @@ func enterContainer(container string,
 
 	execArgs = append(execArgs, command...)
 
-	if emitEscapeSequence {
+	if opts.EmitEscapeSequence {
 		fmt.Printf("\033]777;container;push;%s;toolbox;%s\033\\", container, currentUser.Uid)
 	}
 
@@ -339,9 +482,15 @@ func enterContainer(container string,
 		logrus.Debugf("%s", arg)
 	}
 
-	exitCode, err := shell.RunWithExitCode("podman", os.Stdin, os.Stdout, nil, execArgs...)
+	var exitCode int
 
-	if emitEscapeSequence {
+	if opts.Detach {
+		exitCode, err = shell.RunWithExitCode("podman", os.Stdin, os.Stdout, nil, execArgs...)
+	} else {
+		exitCode, err = shell.RunWithExitCodeAndResize("podman", os.Stdin, os.Stdout, nil, execArgs...)
+	}
+
+	if opts.EmitEscapeSequence {
 		fmt.Printf("\033]777;container;pop;;;%s\033\\", currentUser.Uid)
 	}
 
@@ -355,8 +504,8 @@ func enterContainer(container string,
 	case 126:
 		err = fmt.Errorf("failed to invoke command %s in container %s", command[0], container)
 	case 127:
-		if pathPresent, _ := isPathPresent(container, workingDirectory); !pathPresent {
-			err = fmt.Errorf("directory %s not found in container %s", workingDirectory, container)
+		if pathPresent, _ := isPathPresent(container, opts.Workdir); !pathPresent {
+			err = fmt.Errorf("directory %s not found in container %s", opts.Workdir, container)
 		} else {
 			err = fmt.Errorf("command %s not found in container %s", command[0], container)
 		}
@@ -371,6 +520,19 @@ func enterContainer(container string,
 	return nil
 }
 
+// isInteractiveTTY reports whether stdout is a terminal, as opposed to
+// being redirected to a file or pipe. The detach-keys banner printed by
+// execInContainer is meaningless for a scripted 'toolbox exec' whose
+// output isn't watched by a human, so it's only worth printing here.
+func isInteractiveTTY() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 func enterHelp(cmd *cobra.Command, args []string) {
 	if utils.IsInsideContainer() {
 		if !utils.IsInsideToolboxContainer() {
@@ -0,0 +1,178 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/containers/toolbox/pkg/podman"
+	"github.com/containers/toolbox/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execFlags struct {
+		detach     bool
+		user       string
+		workdir    string
+		env        []string
+		connection string
+		url        string
+		detachKeys string
+	}
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec CONTAINER -- COMMAND [ARG...]",
+	Short: "Run a command inside a toolbox container",
+	RunE:  execRun,
+}
+
+func init() {
+	flags := execCmd.Flags()
+
+	flags.BoolVarP(&execFlags.detach,
+		"detach",
+		"d",
+		false,
+		"Run the command in the background and don't wait for it to finish.")
+
+	flags.StringVarP(&execFlags.user,
+		"user",
+		"u",
+		currentUser.Username,
+		"Run the command as the given user inside the container.")
+
+	flags.StringVarP(&execFlags.workdir,
+		"workdir",
+		"w",
+		workingDirectory,
+		"Run the command from the given working directory inside the container.")
+
+	flags.StringArrayVarP(&execFlags.env,
+		"env",
+		"e",
+		nil,
+		"Set an environment variable inside the container.")
+
+	flags.StringVar(&execFlags.connection,
+		"connection",
+		"",
+		"Run the command on a named remote connection (see connections.conf).")
+
+	flags.StringVar(&execFlags.url,
+		"url",
+		"",
+		"Run the command on a remote podman reachable at the given SSH URL.")
+
+	flags.StringVar(&execFlags.detachKeys,
+		"detach-keys",
+		"",
+		"Override the key sequence for detaching from the container, e.g. 'ctrl-p,ctrl-q'.")
+
+	execCmd.SetHelpFunc(execHelp)
+	rootCmd.AddCommand(execCmd)
+}
+
+func execRun(cmd *cobra.Command, args []string) error {
+	if utils.IsInsideContainer() {
+		if !utils.IsInsideToolboxContainer() {
+			return errors.New("this is not a toolbox container")
+		}
+
+		if _, err := utils.ForwardToHost(); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	dashIndex := cmd.ArgsLenAtDash()
+	if dashIndex <= 0 {
+		return errors.New("missing argument for CONTAINER")
+	}
+
+	if dashIndex == len(args) {
+		return errors.New("missing argument for COMMAND")
+	}
+
+	container := args[0]
+	command := args[dashIndex:]
+
+	if err := resolveTransport(execFlags.connection, execFlags.url); err != nil {
+		return err
+	}
+
+	if _, err := utils.IsContainerNameValid(container); err != nil {
+		var builder strings.Builder
+		fmt.Fprintf(&builder, "invalid argument for 'CONTAINER'\n")
+		fmt.Fprintf(&builder, "Container names must match '%s'\n", utils.ContainerNameRegexp)
+		fmt.Fprintf(&builder, "Run '%s --help' for usage.", executableBase)
+
+		errMsg := builder.String()
+		return errors.New(errMsg)
+	}
+
+	if _, err := podman.ContainerExists(container); err != nil {
+		err := utils.CreateErrorContainerNotFound(container, executableBase)
+		return err
+	}
+
+	detachKeys := execFlags.detachKeys
+	if detachKeys == "" {
+		config, err := utils.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		detachKeys = config.DetachKeys
+	}
+
+	opts := ExecOptions{
+		Detach:     execFlags.detach,
+		User:       execFlags.user,
+		Workdir:    execFlags.workdir,
+		Env:        execFlags.env,
+		DetachKeys: detachKeys,
+	}
+
+	return execInContainer(container, command, opts)
+}
+
+func execHelp(cmd *cobra.Command, args []string) {
+	if utils.IsInsideContainer() {
+		if !utils.IsInsideToolboxContainer() {
+			fmt.Fprintf(os.Stderr, "Error: this is not a toolbox container\n")
+			return
+		}
+
+		if _, err := utils.ForwardToHost(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return
+		}
+
+		return
+	}
+
+	if err := utils.ShowManual("toolbox-exec"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return
+	}
+}
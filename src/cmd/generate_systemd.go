@@ -0,0 +1,145 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/containers/toolbox/pkg/podman"
+	"github.com/containers/toolbox/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateSystemdFlags struct {
+		container string
+		release   string
+		files     bool
+	}
+)
+
+var generateSystemdUnitTemplate = template.Must(template.New("systemd-unit").Parse(`[Unit]
+Description=Toolbox container {{.Container}}
+
+[Service]
+Type=simple
+ExecStart={{.Executable}} run --container {{.Container}} --keep-alive
+ExecStop=podman stop {{.Container}}
+Restart=on-failure
+TimeoutStartSec=30
+
+[Install]
+WantedBy=default.target
+`))
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate systemd units for toolbox containers",
+}
+
+var generateSystemdCmd = &cobra.Command{
+	Use:   "systemd",
+	Short: "Generate a systemd user unit for a toolbox container",
+	RunE:  generateSystemd,
+}
+
+func init() {
+	flags := generateSystemdCmd.Flags()
+
+	flags.StringVarP(&generateSystemdFlags.container,
+		"container",
+		"c",
+		"",
+		"Generate a unit for the toolbox container with the given name.")
+
+	flags.StringVarP(&generateSystemdFlags.release,
+		"release",
+		"r",
+		"",
+		"Generate a unit for a toolbox container for a different operating system release than the host.")
+
+	flags.BoolVar(&generateSystemdFlags.files,
+		"files",
+		false,
+		"Write the generated unit into $XDG_CONFIG_HOME/systemd/user/ instead of printing it to standard output.")
+
+	generateCmd.AddCommand(generateSystemdCmd)
+	rootCmd.AddCommand(generateCmd)
+}
+
+func generateSystemd(cmd *cobra.Command, args []string) error {
+	if utils.IsInsideContainer() {
+		return errors.New("toolbox generate systemd can only be run on the host")
+	}
+
+	container, _, _, err := utils.ResolveContainerAndImageNames(generateSystemdFlags.container,
+		"",
+		generateSystemdFlags.release)
+	if err != nil {
+		return err
+	}
+
+	if _, err := podman.ContainerExists(container); err != nil {
+		err := utils.CreateErrorContainerNotFound(container, executableBase)
+		return err
+	}
+
+	unitName := fmt.Sprintf("toolbox-%s.service", container)
+
+	data := struct {
+		Container  string
+		Executable string
+	}{
+		Container:  container,
+		Executable: executableBase,
+	}
+
+	var unit bytes.Buffer
+	if err := generateSystemdUnitTemplate.Execute(&unit, data); err != nil {
+		return fmt.Errorf("failed to render unit for container %s: %w", container, err)
+	}
+
+	if !generateSystemdFlags.files {
+		fmt.Print(unit.String())
+		return nil
+	}
+
+	configHome, err := utils.GetConfigHome()
+	if err != nil {
+		return err
+	}
+
+	unitDirectory := filepath.Join(configHome, "systemd", "user")
+	if err := os.MkdirAll(unitDirectory, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", unitDirectory, err)
+	}
+
+	unitPath := filepath.Join(unitDirectory, unitName)
+	if err := os.WriteFile(unitPath, unit.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write unit file %s: %w", unitPath, err)
+	}
+
+	fmt.Printf("Unit file written to %s\n", unitPath)
+	fmt.Println("Run 'systemctl --user daemon-reload' to pick it up.")
+
+	return nil
+}
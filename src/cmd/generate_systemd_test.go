@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSystemdUnitTemplate(t *testing.T) {
+	data := struct {
+		Container  string
+		Executable string
+	}{
+		Container:  "fedora-toolbox-39",
+		Executable: "toolbox",
+	}
+
+	var unit bytes.Buffer
+	if err := generateSystemdUnitTemplate.Execute(&unit, data); err != nil {
+		t.Fatalf("failed to render unit: %v", err)
+	}
+
+	rendered := unit.String()
+
+	// The readiness healthcheck (podman.WaitForHealthy) is polled via 'podman inspect',
+	// not via systemd's sd_notify protocol, so the unit must not claim
+	// Type=notify -- that would just time out and fail every start.
+	if strings.Contains(rendered, "Type=notify") {
+		t.Errorf("unit should not use Type=notify, got:\n%s", rendered)
+	}
+
+	if !strings.Contains(rendered, "Type=simple") {
+		t.Errorf("unit should use Type=simple, got:\n%s", rendered)
+	}
+
+	if !strings.Contains(rendered, "ExecStart=toolbox run --container fedora-toolbox-39 --keep-alive") {
+		t.Errorf("unit has unexpected ExecStart line, got:\n%s", rendered)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/containers/toolbox/pkg/podman"
+	"github.com/containers/toolbox/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listFlags struct {
+		checkpoints bool
+	}
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List existing toolbox containers and images",
+	RunE:  list,
+}
+
+func init() {
+	flags := listCmd.Flags()
+
+	flags.BoolVar(&listFlags.checkpoints,
+		"checkpoints",
+		false,
+		"List containers with a checkpoint made by 'toolbox checkpoint', instead of all containers.")
+
+	listCmd.SetHelpFunc(listHelp)
+	rootCmd.AddCommand(listCmd)
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	if listFlags.checkpoints {
+		return listCheckpoints()
+	}
+
+	containers, err := listContainers()
+	if err != nil {
+		return err
+	}
+
+	if len(containers) == 0 {
+		fmt.Println("No toolbox containers found.")
+		return nil
+	}
+
+	for _, container := range containers {
+		fmt.Println(container)
+	}
+
+	return nil
+}
+
+// listContainers returns the names of every toolbox container known to
+// podman, local or remote depending on ActiveTransport. It is also used
+// by enterContainer to decide whether to offer creating a container.
+func listContainers() ([]string, error) {
+	args := podman.ActiveTransport.GlobalArgs()
+	args = append(args, "ps", "--all", "--filter", "label=com.github.containers.toolbox=true",
+		"--format", "{{.Names}}")
+
+	output, err := exec.Command("podman", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// listCheckpoints lists the containers recorded in the checkpoint
+// manifest, i.e. those that have a checkpoint made by
+// 'toolbox checkpoint', each with the path of its most recent one.
+func listCheckpoints() error {
+	manifest, err := loadCheckpointManifest()
+	if err != nil {
+		return err
+	}
+
+	if len(manifest) == 0 {
+		fmt.Println("No checkpoints found.")
+		return nil
+	}
+
+	for container, path := range manifest {
+		fmt.Printf("%s\t%s\n", container, path)
+	}
+
+	return nil
+}
+
+func listHelp(cmd *cobra.Command, args []string) {
+	if err := utils.ShowManual("toolbox-list"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return
+	}
+}
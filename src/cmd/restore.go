@@ -0,0 +1,125 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/containers/toolbox/pkg/podman"
+	"github.com/containers/toolbox/pkg/shell"
+	"github.com/containers/toolbox/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreFlags struct {
+		importPath string
+	}
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore CONTAINER",
+	Short: "Restore a toolbox container previously frozen with 'toolbox checkpoint'",
+	Args:  cobra.ExactArgs(1),
+	RunE:  restore,
+}
+
+func init() {
+	flags := restoreCmd.Flags()
+
+	flags.StringVar(&restoreFlags.importPath,
+		"import",
+		"",
+		"Use the given checkpoint tarball instead of the default checkpoint store. A container that still exists is restored in place and the tarball is not read.")
+
+	restoreCmd.SetHelpFunc(restoreHelp)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func restore(cmd *cobra.Command, args []string) error {
+	if utils.IsInsideContainer() {
+		return errors.New("toolbox restore can only be run on the host")
+	}
+
+	container := args[0]
+
+	if _, err := utils.IsContainerNameValid(container); err != nil {
+		return fmt.Errorf("invalid argument for 'CONTAINER'\nContainer names must match '%s'", utils.ContainerNameRegexp)
+	}
+
+	importPath := restoreFlags.importPath
+	if importPath == "" {
+		var err error
+		importPath, err = resolveCheckpointPath(container)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !utils.PathExists(importPath) {
+		return fmt.Errorf("no checkpoint found for container %s at %s", container, importPath)
+	}
+
+	if err := restoreCheckpoint(container, importPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored container %s from %s\n", container, importPath)
+
+	return nil
+}
+
+// restoreCheckpoint runs 'podman container restore' against a checkpoint
+// tarball. It is also used by enterContainer to transparently resume a
+// container that was left in a checkpointed state.
+//
+// 'podman container checkpoint' does not delete the container; it's left
+// behind in the "exited (checkpointed)" state. So if container still
+// exists, it must be restored in place with a bare 'podman container
+// restore CONTAINER' -- '--import' is only valid, and only needed, once
+// the container has actually been removed and has to be recreated from
+// the tarball.
+func restoreCheckpoint(container, importPath string) error {
+	restoreArgs := podman.ActiveTransport.GlobalArgs()
+	restoreArgs = append(restoreArgs, "container", "restore")
+
+	if _, err := podman.ContainerExists(container); err == nil {
+		restoreArgs = append(restoreArgs, container)
+	} else {
+		restoreArgs = append(restoreArgs, "--import", importPath, container)
+	}
+
+	exitCode, err := shell.RunWithExitCode("podman", nil, os.Stdout, os.Stderr, restoreArgs...)
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("failed to restore container %s", container)
+	}
+
+	return nil
+}
+
+func restoreHelp(cmd *cobra.Command, args []string) {
+	if err := utils.ShowManual("toolbox-restore"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return
+	}
+}
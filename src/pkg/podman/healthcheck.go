@@ -0,0 +1,98 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podman
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HasHealthcheck reports whether the container has a healthcheck
+// configured at all. Nothing in this tree wires a healthcheck into
+// 'podman create'/'podman run' yet (that belongs in 'toolbox create',
+// which isn't part of this checkout), so today this is only ever true
+// for containers someone set up by hand, or for a future Toolbox
+// release that does wire one in. enterContainer therefore calls
+// WaitForHealthy only when this reports true, and otherwise falls back
+// to the stamp file; on a container without a healthcheck,
+// WaitForHealthy would spin for the full timeout and fail even though
+// the container is perfectly usable.
+func HasHealthcheck(container string) (bool, error) {
+	args := ActiveTransport.GlobalArgs()
+	args = append(args, "inspect",
+		"--format", "{{if .Config.Healthcheck}}yes{{end}}",
+		container)
+
+	output, err := exec.Command("podman", args...).Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect healthcheck of container %s: %w", container, err)
+	}
+
+	return strings.TrimSpace(string(output)) == "yes", nil
+}
+
+// WaitForHealthy blocks until the container's healthcheck reports
+// "healthy", or returns an error once timeout elapses. For containers
+// that have a healthcheck, it takes the place of the older scheme of
+// polling for a container-initialized-<pid> stamp file in the shared
+// XDG_RUNTIME_DIR, which raced with the entry point and gave no
+// diagnostics on failure.
+func WaitForHealthy(container string, timeout time.Duration) error {
+	logrus.Debugf("Waiting for container %s to report healthy", container)
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		status, err := healthStatus(container)
+		if err != nil {
+			return err
+		}
+
+		logrus.Debugf("Container %s health status: %s", container, status)
+
+		switch status {
+		case "healthy":
+			return nil
+		case "unhealthy":
+			return fmt.Errorf("container %s failed its readiness healthcheck", container)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for container %s to become healthy", container)
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func healthStatus(container string) (string, error) {
+	args := ActiveTransport.GlobalArgs()
+	args = append(args, "inspect",
+		"--format", "{{.State.Health.Status}}",
+		container)
+
+	output, err := exec.Command("podman", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect health of container %s: %w", container, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
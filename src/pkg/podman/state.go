@@ -0,0 +1,52 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podman
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ContainerState returns the human-readable state podman reports for a
+// container, e.g. "running", "exited", or, after a checkpoint,
+// "exited (checkpointed)".
+func ContainerState(container string) (string, error) {
+	args := ActiveTransport.GlobalArgs()
+	args = append(args, "inspect",
+		"--format", "{{.State.Status}}{{if .State.Checkpointed}} (checkpointed){{end}}",
+		container)
+
+	output, err := exec.Command("podman", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect state of container %s: %w", container, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IsCheckpointed reports whether the container is currently parked in a
+// checkpointed (exited, CRIU-dumped) state, as left behind by
+// 'toolbox checkpoint'.
+func IsCheckpointed(container string) (bool, error) {
+	state, err := ContainerState(container)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.Contains(state, "checkpointed"), nil
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podman
+
+import (
+	"fmt"
+	"os"
+)
+
+// Transport describes where 'podman' commands are routed: the local
+// rootless podman, or a remote one reached over SSH, the same way
+// podman-remote is invoked with '--url'. It is populated once per
+// invocation of toolbox (from '--connection'/'--url' or a configured
+// named connection) and consulted by every helper in this package that
+// shells out to 'podman'.
+type Transport struct {
+	// URL is an SSH URL understood by 'podman --url', e.g.
+	// ssh://user@host:port/run/user/1000/podman/podman.sock.
+	URL string
+
+	// Identity is an optional path to an SSH private key, passed as
+	// 'podman --identity'.
+	Identity string
+}
+
+// IsRemote reports whether commands should be routed to a remote podman
+// instead of the local one.
+func (t Transport) IsRemote() bool {
+	return t.URL != ""
+}
+
+// GlobalArgs returns the 'podman' global arguments needed to reach this
+// transport. They must be inserted before the subcommand (e.g. 'exec',
+// 'inspect') in every invocation.
+func (t Transport) GlobalArgs() []string {
+	if !t.IsRemote() {
+		return nil
+	}
+
+	args := []string{"--url", t.URL}
+
+	if t.Identity != "" {
+		args = append(args, "--identity", t.Identity)
+	}
+
+	return args
+}
+
+// Apply exports this transport as the CONTAINER_HOST and CONTAINER_SSHKEY
+// environment variables, which 'podman' itself reads on every invocation.
+// This means code that shells out to 'podman' without going through
+// GlobalArgs (e.g. helpers in cmd that predate remote support) still
+// reaches the right podman once Apply has been called, since
+// exec.Command inherits the process environment by default.
+func (t Transport) Apply() error {
+	if !t.IsRemote() {
+		return nil
+	}
+
+	if err := os.Setenv("CONTAINER_HOST", t.URL); err != nil {
+		return fmt.Errorf("failed to set CONTAINER_HOST: %w", err)
+	}
+
+	if t.Identity != "" {
+		if err := os.Setenv("CONTAINER_SSHKEY", t.Identity); err != nil {
+			return fmt.Errorf("failed to set CONTAINER_SSHKEY: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ActiveTransport is the transport used for the current invocation of
+// toolbox. It defaults to the zero value, which targets the local
+// podman, and is set early in command execution once '--connection' or
+// '--url' has been resolved against connections.conf.
+//
+// Setting ActiveTransport is not enough on its own: call Apply once it
+// is populated so CONTAINER_HOST/CONTAINER_SSHKEY are in the
+// environment for every subsequent 'podman' invocation in this process,
+// including ones that only use GlobalArgs for display purposes or don't
+// consult it at all.
+var ActiveTransport Transport
@@ -0,0 +1,96 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podman
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTransportIsRemote(t *testing.T) {
+	if (Transport{}).IsRemote() {
+		t.Error("zero-value Transport should not be remote")
+	}
+
+	if !(Transport{URL: "ssh://host/run/podman.sock"}).IsRemote() {
+		t.Error("Transport with a URL should be remote")
+	}
+}
+
+func TestTransportGlobalArgs(t *testing.T) {
+	if args := (Transport{}).GlobalArgs(); args != nil {
+		t.Errorf("local Transport should have no global args, got %v", args)
+	}
+
+	transport := Transport{URL: "ssh://host/run/podman.sock"}
+	got := transport.GlobalArgs()
+	want := []string{"--url", "ssh://host/run/podman.sock"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+
+	transport.Identity = "/home/user/.ssh/id_rsa"
+	got = transport.GlobalArgs()
+	want = []string{"--url", "ssh://host/run/podman.sock", "--identity", "/home/user/.ssh/id_rsa"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestTransportApply(t *testing.T) {
+	t.Setenv("CONTAINER_HOST", "")
+	t.Setenv("CONTAINER_SSHKEY", "")
+
+	if err := (Transport{}).Apply(); err != nil {
+		t.Fatalf("Apply on local Transport should not fail: %v", err)
+	}
+
+	if host := lookupEnv(t, "CONTAINER_HOST"); host != "" {
+		t.Errorf("local Transport should not set CONTAINER_HOST, got %q", host)
+	}
+
+	transport := Transport{URL: "ssh://host/run/podman.sock", Identity: "/home/user/.ssh/id_rsa"}
+	if err := transport.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if host := lookupEnv(t, "CONTAINER_HOST"); host != transport.URL {
+		t.Errorf("CONTAINER_HOST = %q, want %q", host, transport.URL)
+	}
+
+	if key := lookupEnv(t, "CONTAINER_SSHKEY"); key != transport.Identity {
+		t.Errorf("CONTAINER_SSHKEY = %q, want %q", key, transport.Identity)
+	}
+}
+
+func lookupEnv(t *testing.T, key string) string {
+	t.Helper()
+	value, _ := os.LookupEnv(key)
+	return value
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Config holds user preferences persisted in
+// $XDG_CONFIG_HOME/containers/toolbox/toolbox.conf.
+type Config struct {
+	DetachKeys string
+}
+
+// GetConfigPath returns the path to toolbox's own configuration file.
+func GetConfigPath() (string, error) {
+	configHome, err := GetConfigHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configHome, "containers", "toolbox", "toolbox.conf"), nil
+}
+
+// GetConfig reads toolbox.conf. A missing file is not an error; it just
+// means every setting is left at its default.
+func GetConfig() (Config, error) {
+	var config Config
+
+	path, err := GetConfigPath()
+	if err != nil {
+		return config, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	} else if err != nil {
+		return config, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	defer file.Close()
+
+	config, err = parseConfig(file)
+	if err != nil {
+		return config, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// parseConfig parses the 'key = value' lines of a toolbox.conf. It's
+// split out from GetConfig so the parsing logic can be unit tested
+// without touching the real $XDG_CONFIG_HOME.
+func parseConfig(r io.Reader) (Config, error) {
+	var config Config
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "detach-keys":
+			config.DetachKeys = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
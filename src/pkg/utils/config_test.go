@@ -0,0 +1,51 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	input := `
+# a comment
+detach-keys = ctrl-q,ctrl-q
+
+unknown-key = ignored
+`
+
+	config, err := parseConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseConfig failed: %v", err)
+	}
+
+	if config.DetachKeys != "ctrl-q,ctrl-q" {
+		t.Errorf("DetachKeys = %q, want %q", config.DetachKeys, "ctrl-q,ctrl-q")
+	}
+}
+
+func TestParseConfigEmpty(t *testing.T) {
+	config, err := parseConfig(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("parseConfig failed: %v", err)
+	}
+
+	if config.DetachKeys != "" {
+		t.Errorf("DetachKeys = %q, want empty default", config.DetachKeys)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Connection is a named remote toolbox endpoint, the 'toolbox' analogue
+// of a podman-remote system connection.
+type Connection struct {
+	Name     string
+	URL      string
+	Identity string
+}
+
+// GetConnectionsConfigPath returns the path to the file holding named
+// remote connections, $XDG_CONFIG_HOME/containers/toolbox/connections.conf.
+func GetConnectionsConfigPath() (string, error) {
+	configHome, err := GetConfigHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configHome, "containers", "toolbox", "connections.conf"), nil
+}
+
+// GetConnection looks up a named connection in connections.conf. The
+// file holds one connection per line in the form:
+//
+//	name url=ssh://user@host/run/user/1000/podman/podman.sock identity=/path/to/key
+//
+// A missing file is reported the same way as a name that isn't in the
+// file: as a "connection not found" error.
+func GetConnection(name string) (Connection, error) {
+	path, err := GetConnectionsConfigPath()
+	if err != nil {
+		return Connection{}, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return Connection{}, fmt.Errorf("connection %s not found", name)
+	} else if err != nil {
+		return Connection{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	defer file.Close()
+
+	connection, err := parseConnection(file, name)
+	if err != nil {
+		return Connection{}, fmt.Errorf("%w in %s", err, path)
+	}
+
+	return connection, nil
+}
+
+// parseConnection scans a connections.conf for the named connection.
+// It's split out from GetConnection so the parsing logic can be unit
+// tested without touching the real $XDG_CONFIG_HOME.
+func parseConnection(r io.Reader, name string) (Connection, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != name {
+			continue
+		}
+
+		connection := Connection{Name: name}
+
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+
+			switch key {
+			case "url":
+				connection.URL = value
+			case "identity":
+				connection.Identity = value
+			}
+		}
+
+		if connection.URL == "" {
+			return Connection{}, fmt.Errorf("connection %s has no url= set", name)
+		}
+
+		return connection, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Connection{}, fmt.Errorf("failed to read connections: %w", err)
+	}
+
+	return Connection{}, fmt.Errorf("connection %s not found", name)
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2020 Red Hat Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConnectionFound(t *testing.T) {
+	input := `
+# a comment
+work url=ssh://user@host/run/user/1000/podman/podman.sock identity=/home/user/.ssh/id_rsa
+home url=ssh://user@otherhost/run/user/1000/podman/podman.sock
+`
+
+	connection, err := parseConnection(strings.NewReader(input), "home")
+	if err != nil {
+		t.Fatalf("parseConnection failed: %v", err)
+	}
+
+	if connection.Name != "home" {
+		t.Errorf("Name = %q, want %q", connection.Name, "home")
+	}
+
+	if connection.URL != "ssh://user@otherhost/run/user/1000/podman/podman.sock" {
+		t.Errorf("URL = %q, want %q", connection.URL, "ssh://user@otherhost/run/user/1000/podman/podman.sock")
+	}
+
+	if connection.Identity != "" {
+		t.Errorf("Identity = %q, want empty", connection.Identity)
+	}
+}
+
+func TestParseConnectionNotFound(t *testing.T) {
+	input := `work url=ssh://user@host/run/user/1000/podman/podman.sock`
+
+	if _, err := parseConnection(strings.NewReader(input), "missing"); err == nil {
+		t.Error("parseConnection should fail for a connection that isn't configured")
+	}
+}
+
+func TestParseConnectionMissingURL(t *testing.T) {
+	input := `work identity=/home/user/.ssh/id_rsa`
+
+	if _, err := parseConnection(strings.NewReader(input), "work"); err == nil {
+		t.Error("parseConnection should fail for a connection without url=")
+	}
+}